@@ -7,23 +7,28 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/bshakr/koh/internal/git"
+	"github.com/bshakr/koh/internal/multiplexer"
 	"github.com/bshakr/koh/internal/signals"
-	"github.com/bshakr/koh/internal/tmux"
 	"github.com/bshakr/koh/internal/validation"
 	"github.com/spf13/cobra"
 )
 
+var cleanupForce bool
+
 var cleanupCmd = &cobra.Command{
 	Use:   "cleanup [worktree-name]",
 	Short: "Close tmux session and remove worktree",
-	Long: `Remove the git worktree and close its tmux window.
+	Long: `Remove the git worktree and close its tmux window (or zellij tab).
 
-Removal is forced: uncommitted changes in the worktree are discarded
-without prompting. Commit, stash, or push anything you want to keep
-before running cleanup. To bulk-remove only worktrees that are safe to
-delete, use 'koh prune' — it skips worktrees with uncommitted changes.
+Cleanup refuses to run when the worktree has uncommitted changes or
+commits that haven't been pushed. Commit, stash, or push anything you
+want to keep, or pass --force to remove the worktree anyway and discard
+its uncommitted changes. A directory under .koh that git no longer tracks
+as a worktree can't be checked, so removing it also needs --force. To
+bulk-remove only worktrees that are safe to delete, use 'koh prune'.
 
 If no worktree name is provided and you're currently in a worktree,
 the current worktree is cleaned up.
@@ -34,6 +39,7 @@ Not supported on Windows.`,
 }
 
 func init() {
+	cleanupCmd.Flags().BoolVarP(&cleanupForce, "force", "f", false, "Remove the worktree even if it has uncommitted changes or unpushed commits")
 	rootCmd.AddCommand(cleanupCmd)
 }
 
@@ -75,6 +81,17 @@ func runCleanup(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid worktree name: %w", err)
 	}
 
+	mux, err := multiplexer.Detect()
+	if err != nil {
+		return err
+	}
+	// Messages that name the window keep the original tmux wording when
+	// there's no multiplexer to name.
+	windowKind := "tmux"
+	if mux.IsInside() {
+		windowKind = mux.Name()
+	}
+
 	// Set up context with cancellation for long-running operations and signal handling
 	ctx, cleanup := signals.SetupCancellableContext()
 	defer cleanup()
@@ -104,7 +121,21 @@ func runCleanup(_ *cobra.Command, args []string) error {
 		worktreeExists = false
 		if !registered {
 			fmt.Printf("Warning: Worktree %s not found\n", worktreeName)
-			fmt.Println("Will attempt to clean up tmux window only")
+			fmt.Printf("Will attempt to clean up %s window only\n", windowKind)
+		}
+	}
+
+	// Refuse to discard work before touching anything (including the cwd).
+	// An unregistered directory can't be checked at all — git no longer
+	// tracks it, so git commands there would inspect the main repo instead —
+	// so removing one also needs --force.
+	if worktreeExists && !cleanupForce {
+		if !registered {
+			return fmt.Errorf("%s is not a registered git worktree, so koh can't check it for uncommitted or unpushed work\nUse 'koh cleanup --force %s' to remove it anyway",
+				worktreePath, worktreeName)
+		}
+		if err := checkWorktreeSafeToRemove(ctx, worktreeName, worktreePath); err != nil {
+			return err
 		}
 	}
 
@@ -146,7 +177,7 @@ func runCleanup(_ *cobra.Command, args []string) error {
 			fmt.Printf("Warning: Failed to remove worktree directory: %v\n", err)
 		}
 
-		// Verify before touching tmux, so a failure (and its output) doesn't
+		// Verify before touching the window, so a failure (and its output) doesn't
 		// die with the window we're about to close.
 		if _, err := os.Stat(worktreePath); err == nil {
 			return fmt.Errorf("worktree directory still exists after cleanup: %s", worktreePath)
@@ -168,9 +199,9 @@ func runCleanup(_ *cobra.Command, args []string) error {
 		}
 	}
 
-	// Step 3: Close tmux window (tmux will automatically switch to previous window)
+	// Step 3: Close the window (the multiplexer automatically switches to the previous one)
 	windowClosed := false
-	if tmux.IsInTmux() {
+	if mux.IsInside() {
 		repoName, err := git.GetRepoName()
 		if err != nil {
 			fmt.Printf("Warning: Failed to get repository name: %v\n", err)
@@ -178,11 +209,12 @@ func runCleanup(_ *cobra.Command, args []string) error {
 		}
 
 		windowName := fmt.Sprintf("%s|%s", repoName, worktreeName)
-		if err := tmux.CloseWindow(windowName, worktreeName); err != nil {
+		if err := mux.CloseWindow(windowName, worktreeName); err != nil {
 			fmt.Printf("Warning: %v\n", err)
 		} else {
 			windowClosed = true
-			fmt.Println("Tmux window closed (switched to previous window)")
+			name := mux.Name()
+			fmt.Printf("%s window closed (switched to previous window)\n", strings.ToUpper(name[:1])+name[1:])
 		}
 	} else {
 		fmt.Println("Not in a tmux session, skipping tmux cleanup")
@@ -191,13 +223,39 @@ func runCleanup(_ *cobra.Command, args []string) error {
 	// Exit non-zero when there was nothing to clean at all, instead of
 	// reporting success for a worktree that was never found.
 	if !worktreeExists && !registered && !windowClosed {
-		return fmt.Errorf("nothing to clean up for %q: no worktree, registration, or tmux window found", worktreeName)
+		return fmt.Errorf("nothing to clean up for %q: no worktree, registration, or %s window found", worktreeName, windowKind)
 	}
 
 	fmt.Println("Cleanup complete!")
 	return nil
 }
 
+// checkWorktreeSafeToRemove returns an error listing what would be lost when
+// the worktree has uncommitted changes or unpushed commits.
+func checkWorktreeSafeToRemove(ctx context.Context, worktreeName, worktreePath string) error {
+	dirty, err := git.HasUncommittedChanges(ctx, worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to check for uncommitted changes: %w\nUse --force to remove the worktree anyway", err)
+	}
+	unpushed, err := git.HasUnpushedCommits(ctx, worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to check for unpushed commits: %w\nUse --force to remove the worktree anyway", err)
+	}
+
+	var lost []string
+	if dirty {
+		lost = append(lost, "  - uncommitted changes (these will be discarded)")
+	}
+	if unpushed {
+		lost = append(lost, "  - commits that haven't been pushed")
+	}
+	if len(lost) == 0 {
+		return nil
+	}
+	return fmt.Errorf("worktree %s has work that would be lost:\n%s\nCommit and push your work, or use 'koh cleanup --force %s' to remove it anyway",
+		worktreeName, strings.Join(lost, "\n"), worktreeName)
+}
+
 // findKohWorktreeByName looks up a registered worktree by directory basename,
 // limited to this repo's .koh (or legacy .ko) directory. It returns the
 // registered path and whether a registration exists — the directory itself
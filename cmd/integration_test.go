@@ -1,13 +1,14 @@
 package cmd
 
-// Integration tests for koh cleanup and koh prune. Each test builds a real
+// Integration tests for koh cleanup, prune, and list. Each test builds a real
 // git repository (with a local bare origin) in t.TempDir() and drives the
-// actual command flows. TMUX is forced empty so no test can ever reach a
-// real tmux server, and git config is isolated from the host machine.
+// actual command flows. TMUX and ZELLIJ are forced empty so no test can ever
+// reach a real multiplexer, and git config is isolated from the host machine.
 
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"os"
 	"os/exec"
@@ -43,8 +44,10 @@ func setupRepo(t *testing.T) string {
 	if runtime.GOOS == "windows" {
 		t.Skip("cleanup/prune are not supported on Windows")
 	}
-	// Never let a test reach a real tmux server or the host's git config.
+	// Never let a test reach a real multiplexer or the host's git config.
 	t.Setenv("TMUX", "")
+	t.Setenv("ZELLIJ", "")
+	t.Setenv("KOH_MULTIPLEXER", "")
 	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
 	t.Setenv("GIT_CONFIG_SYSTEM", os.DevNull)
 
@@ -80,6 +83,14 @@ func setPruneFlags(t *testing.T, dryRun, yes, deleteBranch, noFetch bool) {
 	})
 }
 
+// setCleanupForce sets the package-level cleanup --force flag for one test.
+func setCleanupForce(t *testing.T, force bool) {
+	t.Helper()
+	old := cleanupForce
+	cleanupForce = force
+	t.Cleanup(func() { cleanupForce = old })
+}
+
 func isRegistered(t *testing.T, repo, name string) bool {
 	t.Helper()
 	out := gitRun(t, repo, "worktree", "list", "--porcelain")
@@ -295,6 +306,8 @@ func TestCleanupRemovesWorktreeWithReadOnlyDir(t *testing.T) {
 	if err := os.Chmod(filepath.Join(wt, "cache"), 0o555); err != nil {
 		t.Fatal(err)
 	}
+	// The cache files are untracked changes, so cleanup needs --force.
+	setCleanupForce(t, true)
 
 	t.Chdir(repo)
 	if err := runCleanup(nil, []string{"wt-ro"}); err != nil {
@@ -338,6 +351,8 @@ func TestCleanupRemovesOrphanedDirectory(t *testing.T) {
 	if _, err := os.Stat(wt); err != nil {
 		t.Skip("git fully removed the read-only worktree on this platform; orphan scenario not reproducible")
 	}
+	// Without a registration git can't vouch for the files, so cleanup needs --force.
+	setCleanupForce(t, true)
 
 	t.Chdir(repo)
 	if err := runCleanup(nil, []string{"wt-orphan"}); err != nil {
@@ -346,6 +361,33 @@ func TestCleanupRemovesOrphanedDirectory(t *testing.T) {
 	assertDirGone(t, wt)
 }
 
+func TestCleanupRefusesUnregisteredDirectory(t *testing.T) {
+	repo := setupRepo(t)
+	dir := filepath.Join(repo, ".koh", "wt-plain")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(repo)
+	err := runCleanup(nil, []string{"wt-plain"})
+	if err == nil {
+		t.Fatal("expected cleanup to refuse an unregistered directory without --force")
+	}
+	if !strings.Contains(err.Error(), "not a registered git worktree") || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected error to explain the missing registration and --force, got: %v", err)
+	}
+	assertDirExists(t, filepath.Join(dir, "notes.txt"))
+
+	setCleanupForce(t, true)
+	if err := runCleanup(nil, []string{"wt-plain"}); err != nil {
+		t.Fatalf("runCleanup --force: %v", err)
+	}
+	assertDirGone(t, dir)
+}
+
 func TestCleanupPrunesStaleRegistration(t *testing.T) {
 	repo := setupRepo(t)
 	wt := addWorktree(t, repo, ".koh", "wt-stale")
@@ -384,6 +426,103 @@ func TestCleanupFromInsideTargetWorktree(t *testing.T) {
 	assertDirGone(t, wt)
 }
 
+func TestCleanupRefusesUncommittedChanges(t *testing.T) {
+	repo := setupRepo(t)
+	wt := addWorktree(t, repo, ".koh", "wt-dirty")
+	if err := os.WriteFile(filepath.Join(wt, "wip.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run from inside the worktree: the refusal must happen before cleanup
+	// switches to the main repo, leaving the user where they started.
+	t.Chdir(wt)
+	err := runCleanup(nil, []string{"wt-dirty"})
+	if err == nil {
+		t.Fatal("expected cleanup to refuse a worktree with uncommitted changes")
+	}
+	if !strings.Contains(err.Error(), "uncommitted changes") || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected error to name the uncommitted changes and --force, got: %v", err)
+	}
+	assertDirExists(t, filepath.Join(wt, "wip.txt"))
+	if cwd, _ := os.Getwd(); !samePath(cwd, wt) {
+		t.Errorf("expected cwd to stay at %s, got %s", wt, cwd)
+	}
+}
+
+func TestCleanupRefusesUnpushedCommits(t *testing.T) {
+	repo := setupRepo(t)
+	wt := addWorktree(t, repo, ".koh", "wt-unpushed")
+	gitRun(t, wt, "commit", "-q", "--allow-empty", "-m", "local work")
+
+	t.Chdir(repo)
+	err := runCleanup(nil, []string{"wt-unpushed"})
+	if err == nil {
+		t.Fatal("expected cleanup to refuse a worktree with unpushed commits")
+	}
+	if !strings.Contains(err.Error(), "pushed") {
+		t.Errorf("expected error to mention unpushed commits, got: %v", err)
+	}
+	assertDirExists(t, wt)
+}
+
+func TestCleanupRefusesCommitsAheadOfUpstream(t *testing.T) {
+	repo := setupRepo(t)
+	wt := addWorktree(t, repo, ".koh", "wt-ahead")
+	gitRun(t, wt, "commit", "-q", "--allow-empty", "-m", "pushed work")
+	gitRun(t, wt, "push", "-q", "-u", "origin", "wt-ahead")
+	gitRun(t, wt, "commit", "-q", "--allow-empty", "-m", "local work")
+
+	t.Chdir(repo)
+	if err := runCleanup(nil, []string{"wt-ahead"}); err == nil {
+		t.Fatal("expected cleanup to refuse a branch ahead of its upstream")
+	}
+	assertDirExists(t, wt)
+}
+
+func TestCleanupAllowsPushedBranch(t *testing.T) {
+	repo := setupRepo(t)
+	wt := addWorktree(t, repo, ".koh", "wt-pushed")
+	gitRun(t, wt, "commit", "-q", "--allow-empty", "-m", "pushed work")
+	gitRun(t, wt, "push", "-q", "-u", "origin", "wt-pushed")
+
+	t.Chdir(repo)
+	if err := runCleanup(nil, []string{"wt-pushed"}); err != nil {
+		t.Fatalf("runCleanup: %v", err)
+	}
+	assertDirGone(t, wt)
+}
+
+func TestCleanupAllowsBranchPushedWithoutUpstream(t *testing.T) {
+	repo := setupRepo(t)
+	wt := addWorktree(t, repo, ".koh", "wt-no-u")
+	gitRun(t, wt, "commit", "-q", "--allow-empty", "-m", "pushed work")
+	// Pushed without -u: no upstream is configured, but origin/wt-no-u has
+	// every commit, so nothing would be lost.
+	gitRun(t, wt, "push", "-q", "origin", "wt-no-u")
+
+	t.Chdir(repo)
+	if err := runCleanup(nil, []string{"wt-no-u"}); err != nil {
+		t.Fatalf("runCleanup: %v", err)
+	}
+	assertDirGone(t, wt)
+}
+
+func TestCleanupForceDiscardsWork(t *testing.T) {
+	repo := setupRepo(t)
+	wt := addWorktree(t, repo, ".koh", "wt-forced")
+	gitRun(t, wt, "commit", "-q", "--allow-empty", "-m", "local work")
+	if err := os.WriteFile(filepath.Join(wt, "wip.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	setCleanupForce(t, true)
+
+	t.Chdir(repo)
+	if err := runCleanup(nil, []string{"wt-forced"}); err != nil {
+		t.Fatalf("runCleanup: %v", err)
+	}
+	assertDirGone(t, wt)
+}
+
 func TestCleanupNotFoundReturnsError(t *testing.T) {
 	repo := setupRepo(t)
 
@@ -393,6 +532,66 @@ func TestCleanupNotFoundReturnsError(t *testing.T) {
 	}
 }
 
+// --- list ---
+
+// setListJSON sets the package-level --json flag for one test.
+func setListJSON(t *testing.T, v bool) {
+	t.Helper()
+	old := listJSON
+	listJSON = v
+	t.Cleanup(func() { listJSON = old })
+}
+
+func TestListJSONReportsBranchAndDirty(t *testing.T) {
+	repo := setupRepo(t)
+	addWorktree(t, repo, ".koh", "wt-clean")
+	dirty := addWorktree(t, repo, ".koh", "wt-dirty")
+	if err := os.WriteFile(filepath.Join(dirty, "wip.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	setListJSON(t, true)
+
+	t.Chdir(repo)
+	var runErr error
+	out := captureOutput(t, func() { runErr = runList(nil, nil) })
+	if runErr != nil {
+		t.Fatalf("runList: %v", runErr)
+	}
+
+	var entries []listEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+	byName := map[string]listEntry{}
+	for _, e := range entries {
+		byName[e.Name] = e
+	}
+	if len(byName) != 2 {
+		t.Fatalf("expected 2 worktrees, got %+v", entries)
+	}
+	if e := byName["wt-clean"]; e.Branch != "wt-clean" || e.Dirty || e.Window || e.Multiplexer != "" {
+		t.Errorf("unexpected entry for clean worktree: %+v", e)
+	}
+	if e := byName["wt-dirty"]; !e.Dirty {
+		t.Errorf("expected wt-dirty to be reported dirty: %+v", e)
+	}
+}
+
+func TestListJSONWithoutKohDir(t *testing.T) {
+	repo := setupRepo(t)
+	setListJSON(t, true)
+
+	t.Chdir(repo)
+	var runErr error
+	out := captureOutput(t, func() { runErr = runList(nil, nil) })
+	if runErr != nil {
+		t.Fatalf("runList: %v", runErr)
+	}
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("expected an empty JSON array, got %q", out)
+	}
+}
+
 // --- new ---
 
 // TestNewRefusesOutsideTmux verifies that koh new fails fast when not run from
@@ -2,27 +2,36 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/bshakr/koh/internal/git"
+	"github.com/bshakr/koh/internal/multiplexer"
 	"github.com/bshakr/koh/internal/styles"
-	"github.com/bshakr/koh/internal/tmux"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 )
 
+var listJSON bool
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all koh worktrees",
-	Long:  `List all git worktrees in the .koh directory. Use arrow keys or j/k to navigate, g/G to jump, Enter to switch, q to quit.`,
-	RunE:  runList,
+	Long: `List all git worktrees in the .koh directory with their branch, whether
+they have a live tmux window (or zellij tab), and whether their working tree is dirty.
+
+Use arrow keys or j/k to navigate, g/G to jump, Enter to switch, q to quit.
+Use --json to print the list non-interactively for scripting.`,
+	Args: cobra.NoArgs,
+	RunE: runList,
 }
 
 func init() {
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print worktrees as JSON instead of the interactive list")
 	rootCmd.AddCommand(listCmd)
 }
 
@@ -32,17 +41,34 @@ type worktreeItem struct {
 	branch    string
 	path      string
 	isCurrent bool
+	hasWindow bool
+	dirty     bool
 	reasons   []git.PruneReason
 }
 
+// listEntry is the --json representation of a worktreeItem. Multiplexer names
+// the backend Window refers to and is empty outside a multiplexer.
+type listEntry struct {
+	Name        string   `json:"name"`
+	Branch      string   `json:"branch"`
+	Path        string   `json:"path"`
+	Current     bool     `json:"current"`
+	Window      bool     `json:"window"`
+	Multiplexer string   `json:"multiplexer"`
+	Dirty       bool     `json:"dirty"`
+	Reasons     []string `json:"reasons"`
+}
+
 // listModel is the bubbletea model for the interactive worktree list
 type listModel struct {
 	worktrees     []worktreeItem
 	cursor        int
 	selected      string
 	quitting      bool
-	inTmux        bool
+	inMultiplexer bool
+	muxName       string
 	switchSuccess bool
+	width         int
 }
 
 func runList(_ *cobra.Command, _ []string) error {
@@ -71,6 +97,9 @@ func runList(_ *cobra.Command, _ []string) error {
 	koDir := filepath.Join(mainRepoRoot, ".koh")
 	if _, err := os.Stat(koDir); err != nil {
 		if os.IsNotExist(err) {
+			if listJSON {
+				return printListJSON(nil, "")
+			}
 			fmt.Println(styles.Muted.Render("No worktrees found (no .koh directory)"))
 			return nil
 		}
@@ -92,19 +121,55 @@ func runList(_ *cobra.Command, _ []string) error {
 	classified, err := git.ClassifyWorktrees(ctx, kohWorktrees)
 	if err != nil {
 		// Fall back to unclassified data — reason tags will be empty but the list still works.
-		fmt.Println(styles.Muted.Render(fmt.Sprintf("Warning: could not classify worktrees: %v", err)))
+		// Warnings go to stderr so they never corrupt --json output.
+		fmt.Fprintln(os.Stderr, styles.Muted.Render(fmt.Sprintf("Warning: could not classify worktrees: %v", err)))
 		classified = kohWorktrees
 	}
 
+	// Check if in tmux or zellij for switching functionality and window status
+	mux, err := multiplexer.Detect()
+	if err != nil {
+		return err
+	}
+	inMultiplexer := mux.IsInside()
+
+	// List windows once up front rather than querying per worktree.
+	var windows map[string]bool
+	if inMultiplexer {
+		windows, err = mux.WorktreeWindows(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, styles.Muted.Render(fmt.Sprintf("Warning: could not list %s windows: %v", mux.Name(), err)))
+		}
+	}
+
 	var worktrees []worktreeItem
 	for _, wt := range classified {
-		worktrees = append(worktrees, worktreeItem{
-			name:      filepath.Base(wt.Path),
+		name := filepath.Base(wt.Path)
+		item := worktreeItem{
+			name:      name,
 			branch:    displayBranch(wt),
 			path:      wt.Path,
 			isCurrent: currentWorktreePath != "" && samePath(wt.Path, currentWorktreePath),
+			hasWindow: windows[name],
 			reasons:   wt.Reasons,
-		})
+		}
+		// A gone worktree has no working tree to inspect.
+		if !wt.HasReason(git.ReasonGone) {
+			dirty, err := git.HasUncommittedChanges(ctx, wt.Path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, styles.Muted.Render(fmt.Sprintf("Warning: %v", err)))
+			}
+			item.dirty = dirty
+		}
+		worktrees = append(worktrees, item)
+	}
+
+	if listJSON {
+		jsonMux := ""
+		if inMultiplexer {
+			jsonMux = mux.Name()
+		}
+		return printListJSON(worktrees, jsonMux)
 	}
 
 	if len(worktrees) == 0 {
@@ -112,14 +177,13 @@ func runList(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	// Check if in tmux for switching functionality
-	inTmux := tmux.IsInTmux()
-
 	// Create and run the interactive list
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    0,
-		inTmux:    inTmux,
+		worktrees:     worktrees,
+		cursor:        0,
+		inMultiplexer: inMultiplexer,
+		muxName:       mux.Name(),
+		width:         styles.GetTerminalWidth(),
 	}
 
 	// Set cursor to current worktree if found
@@ -138,7 +202,7 @@ func runList(_ *cobra.Command, _ []string) error {
 
 	// Check if user selected a worktree to switch to
 	if finalModel, ok := finalModel.(listModel); ok {
-		if finalModel.selected != "" && inTmux {
+		if finalModel.selected != "" && inMultiplexer {
 			// Switch to the selected worktree using the extracted function
 			return switchToWorktree(finalModel.selected, true)
 		}
@@ -147,6 +211,107 @@ func runList(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// printListJSON writes worktrees to stdout as a JSON array. An empty list
+// prints "[]" rather than "null" so scripts can always iterate the result.
+// muxName is the multiplexer the window statuses came from, if any.
+func printListJSON(worktrees []worktreeItem, muxName string) error {
+	entries := make([]listEntry, 0, len(worktrees))
+	for _, wt := range worktrees {
+		entries = append(entries, listEntry{
+			Name:        wt.name,
+			Branch:      wt.branch,
+			Path:        wt.path,
+			Current:     wt.isCurrent,
+			Window:      wt.hasWindow,
+			Multiplexer: muxName,
+			Dirty:       wt.dirty,
+			Reasons:     reasonStrings(wt.reasons),
+		})
+	}
+
+	out, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode worktrees as JSON: %w", err)
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// listColumnWidths returns the widths to pad the name and branch columns to
+// so rows line up. Both are 0 (no padding) when an aligned row would not fit
+// in terminalWidth, so narrow terminals get compact rows instead of wrapping.
+// A terminalWidth of 0 means unknown and always aligns. windowTag is the
+// label shown for worktrees with a live window.
+func listColumnWidths(worktrees []worktreeItem, windowTag string, terminalWidth int) (nameWidth, branchWidth int) {
+	longestTags := 0
+	for _, wt := range worktrees {
+		nameWidth = max(nameWidth, lipgloss.Width(wt.name))
+		branchWidth = max(branchWidth, lipgloss.Width(styles.IconBranch+" "+wt.branch))
+		longestTags = max(longestTags, lipgloss.Width(listStatusText(wt, windowTag)))
+	}
+
+	// cursor (2) + icon and separators (4) + "[current]" (10)
+	const fixed = 16
+	if terminalWidth > 0 && fixed+nameWidth+branchWidth+longestTags > terminalWidth {
+		return 0, 0
+	}
+	return nameWidth, branchWidth
+}
+
+// listStatusText is the unstyled status column for a worktree, used for
+// width calculations.
+func listStatusText(wt worktreeItem, windowTag string) string {
+	var parts []string
+	if wt.hasWindow {
+		parts = append(parts, windowTag)
+	}
+	if wt.dirty {
+		parts = append(parts, "dirty")
+	}
+	if len(wt.reasons) > 0 {
+		parts = append(parts, "("+strings.Join(reasonStrings(wt.reasons), ", ")+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+func reasonStrings(reasons []git.PruneReason) []string {
+	out := make([]string, len(reasons))
+	for i, r := range reasons {
+		out[i] = string(r)
+	}
+	return out
+}
+
+var (
+	windowTagStyle = lipgloss.NewStyle().Foreground(styles.Success)
+	dirtyTagStyle  = lipgloss.NewStyle().Foreground(styles.Warning)
+)
+
+// renderListStatus renders the window, dirty, and prune reason tags. The
+// window tag is the multiplexer's name, e.g. "tmux" or "zellij".
+func renderListStatus(wt worktreeItem, windowTag string) string {
+	var parts []string
+	if wt.hasWindow {
+		parts = append(parts, windowTagStyle.Render(windowTag))
+	}
+	if wt.dirty {
+		parts = append(parts, dirtyTagStyle.Render("dirty"))
+	}
+	if reasonTag := renderReasons(wt.reasons); reasonTag != "" {
+		parts = append(parts, reasonTag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// padRight pads styled with spaces to width terminal cells, measured on raw
+// (the unstyled text) so ANSI escapes don't skew alignment.
+func padRight(styled, raw string, width int) string {
+	if gap := width - lipgloss.Width(raw); gap > 0 {
+		return styled + strings.Repeat(" ", gap)
+	}
+	return styled
+}
+
 // Init initializes the bubbletea model
 func (m listModel) Init() tea.Cmd {
 	return nil
@@ -155,6 +320,9 @@ func (m listModel) Init() tea.Cmd {
 // Update handles keyboard input and updates the model
 func (m listModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		// Quit keys
@@ -193,7 +361,7 @@ func (m listModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Select and switch
 		case "enter":
 			// Defensive check (should always be true due to navigation bounds and empty list early return)
-			if m.inTmux && m.cursor >= 0 && m.cursor < len(m.worktrees) {
+			if m.inMultiplexer && m.cursor >= 0 && m.cursor < len(m.worktrees) {
 				m.selected = m.worktrees[m.cursor].name
 				m.switchSuccess = true
 				return m, tea.Quit
@@ -217,6 +385,7 @@ func (m listModel) View() string {
 	s.WriteString("\n" + title + "\n\n")
 
 	// Worktrees list
+	nameWidth, branchWidth := listColumnWidths(m.worktrees, m.muxName, m.width)
 	for i, wt := range m.worktrees {
 		cursor := "  "
 		if m.cursor == i {
@@ -224,7 +393,8 @@ func (m listModel) View() string {
 		}
 
 		var line string
-		reasonTag := renderReasons(wt.reasons)
+		branchText := styles.IconBranch + " " + wt.branch
+		status := renderListStatus(wt, m.muxName)
 		if wt.isCurrent {
 			// Current session in green text (no background)
 			greenStyle := lipgloss.NewStyle().
@@ -232,15 +402,19 @@ func (m listModel) View() string {
 				Foreground(lipgloss.Color("2")) // Green
 
 			icon := greenStyle.Render(styles.IconCurrent)
-			nameStyled := greenStyle.Render(wt.name)
-			branchStyled := greenStyle.Render(styles.IconBranch + " " + wt.branch)
+			nameStyled := padRight(greenStyle.Render(wt.name), wt.name, nameWidth)
+			branchStyled := padRight(greenStyle.Render(branchText), branchText, branchWidth)
 			currentLabel := styles.Muted.Render("[current]")
-			line = fmt.Sprintf("%s%s %s %s %s %s", cursor, icon, nameStyled, branchStyled, currentLabel, reasonTag)
+			line = fmt.Sprintf("%s%s %s %s %s %s", cursor, icon, nameStyled, branchStyled, currentLabel, status)
 		} else {
 			icon := styles.Muted.Render(styles.IconBullet)
-			nameStyled := wt.name
-			branchStyled := styles.Muted.Render(styles.IconBranch + " " + wt.branch)
-			line = fmt.Sprintf("%s%s %s %s %s", cursor, icon, nameStyled, branchStyled, reasonTag)
+			nameStyled := padRight(wt.name, wt.name, nameWidth)
+			branchStyled := padRight(styles.Muted.Render(branchText), branchText, branchWidth)
+			if branchWidth > 0 {
+				// Keep the status column aligned with rows that carry "[current]".
+				branchStyled += strings.Repeat(" ", lipgloss.Width(" [current]"))
+			}
+			line = fmt.Sprintf("%s%s %s %s %s", cursor, icon, nameStyled, branchStyled, status)
 		}
 		line = strings.TrimRight(line, " ")
 
@@ -249,7 +423,7 @@ func (m listModel) View() string {
 
 	// Help text
 	s.WriteString("\n")
-	if m.inTmux {
+	if m.inMultiplexer {
 		help := styles.RenderHelp("↑/↓ or j/k: navigate • g/G: jump to top/bottom • enter: switch • q: quit")
 		s.WriteString(help)
 	} else {
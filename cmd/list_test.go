@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestListModelInit(t *testing.T) {
@@ -11,7 +13,7 @@ func TestListModelInit(t *testing.T) {
 		worktrees: []worktreeItem{
 			{name: "test1", branch: "main", path: "/path/1", isCurrent: false},
 		},
-		inTmux: true,
+		inMultiplexer: true,
 	}
 
 	cmd := m.Init()
@@ -28,9 +30,9 @@ func TestListModelNavigationDown(t *testing.T) {
 	}
 
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    0,
-		inTmux:    true,
+		worktrees:     worktrees,
+		cursor:        0,
+		inMultiplexer: true,
 	}
 
 	// Test: Down arrow navigation
@@ -63,9 +65,9 @@ func TestListModelNavigationUp(t *testing.T) {
 	}
 
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    2,
-		inTmux:    true,
+		worktrees:     worktrees,
+		cursor:        2,
+		inMultiplexer: true,
 	}
 
 	// Test: Up arrow navigation
@@ -98,9 +100,9 @@ func TestListModelJumpToTop(t *testing.T) {
 	}
 
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    2,
-		inTmux:    true,
+		worktrees:     worktrees,
+		cursor:        2,
+		inMultiplexer: true,
 	}
 
 	// Test: g jumps to top
@@ -127,9 +129,9 @@ func TestListModelJumpToBottom(t *testing.T) {
 	}
 
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    0,
-		inTmux:    true,
+		worktrees:     worktrees,
+		cursor:        0,
+		inMultiplexer: true,
 	}
 
 	// Test: G jumps to bottom
@@ -155,9 +157,9 @@ func TestListModelSelection(t *testing.T) {
 	}
 
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    1,
-		inTmux:    true,
+		worktrees:     worktrees,
+		cursor:        1,
+		inMultiplexer: true,
 	}
 
 	// Test: Enter selects worktree
@@ -181,9 +183,9 @@ func TestListModelSelectionNotInTmux(t *testing.T) {
 	}
 
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    0,
-		inTmux:    false, // Not in tmux
+		worktrees:     worktrees,
+		cursor:        0,
+		inMultiplexer: false, // Not in tmux
 	}
 
 	// Test: Enter should not select when not in tmux
@@ -203,8 +205,8 @@ func TestListModelSelectionNotInTmux(t *testing.T) {
 
 func TestListModelQuit(t *testing.T) {
 	m := listModel{
-		worktrees: []worktreeItem{{name: "test", branch: "main", path: "/", isCurrent: false}},
-		inTmux:    true,
+		worktrees:     []worktreeItem{{name: "test", branch: "main", path: "/", isCurrent: false}},
+		inMultiplexer: true,
 	}
 
 	tests := []struct {
@@ -238,9 +240,9 @@ func TestListModelView(t *testing.T) {
 	}
 
 	m := listModel{
-		worktrees: worktrees,
-		cursor:    0,
-		inTmux:    true,
+		worktrees:     worktrees,
+		cursor:        0,
+		inMultiplexer: true,
 	}
 
 	view := m.View()
@@ -265,9 +267,9 @@ func TestListModelView(t *testing.T) {
 
 func TestListModelViewQuitting(t *testing.T) {
 	m := listModel{
-		worktrees: []worktreeItem{{name: "test", branch: "main", path: "/", isCurrent: false}},
-		quitting:  true,
-		inTmux:    true,
+		worktrees:     []worktreeItem{{name: "test", branch: "main", path: "/", isCurrent: false}},
+		quitting:      true,
+		inMultiplexer: true,
 	}
 
 	view := m.View()
@@ -280,8 +282,8 @@ func TestListModelViewQuitting(t *testing.T) {
 
 func TestListModelViewNotInTmux(t *testing.T) {
 	m := listModel{
-		worktrees: []worktreeItem{{name: "test", branch: "main", path: "/", isCurrent: false}},
-		inTmux:    false,
+		worktrees:     []worktreeItem{{name: "test", branch: "main", path: "/", isCurrent: false}},
+		inMultiplexer: false,
 	}
 
 	view := m.View()
@@ -294,9 +296,9 @@ func TestListModelViewNotInTmux(t *testing.T) {
 
 func TestListModelEmptyWorktreesList(t *testing.T) {
 	m := listModel{
-		worktrees: []worktreeItem{},
-		cursor:    0,
-		inTmux:    true,
+		worktrees:     []worktreeItem{},
+		cursor:        0,
+		inMultiplexer: true,
 	}
 
 	// Navigation should handle empty list gracefully
@@ -317,6 +319,67 @@ func TestListModelEmptyWorktreesList(t *testing.T) {
 	}
 }
 
+func TestListModelViewShowsStatus(t *testing.T) {
+	m := listModel{
+		worktrees: []worktreeItem{
+			{name: "live", branch: "feature", path: "/path/1", hasWindow: true},
+			{name: "edited", branch: "dev", path: "/path/2", dirty: true},
+		},
+		inMultiplexer: true,
+		muxName:       "tmux",
+	}
+
+	view := m.View()
+	if !contains(view, "tmux") {
+		t.Error("Expected view to show the tmux window tag")
+	}
+	if !contains(view, "dirty") {
+		t.Error("Expected view to show the dirty tag")
+	}
+}
+
+func TestListColumnWidths(t *testing.T) {
+	worktrees := []worktreeItem{
+		{name: "a", branch: "main"},
+		{name: "longer-name", branch: "feature/x", dirty: true},
+	}
+
+	nameWidth, branchWidth := listColumnWidths(worktrees, "tmux", 120)
+	if nameWidth != len("longer-name") {
+		t.Errorf("Expected name width %d, got %d", len("longer-name"), nameWidth)
+	}
+	if want := len("feature/x") + 2; branchWidth != want {
+		t.Errorf("Expected branch width %d, got %d", want, branchWidth)
+	}
+
+	// Too narrow for aligned rows: fall back to compact, unpadded output.
+	nameWidth, branchWidth = listColumnWidths(worktrees, "tmux", 20)
+	if nameWidth != 0 || branchWidth != 0 {
+		t.Errorf("Expected no padding on a narrow terminal, got name=%d branch=%d", nameWidth, branchWidth)
+	}
+}
+
+func TestListModelViewAlignsColumns(t *testing.T) {
+	m := listModel{
+		worktrees: []worktreeItem{
+			{name: "a", branch: "main", dirty: true},
+			{name: "longer-name", branch: "feature", dirty: true},
+		},
+		inMultiplexer: true,
+		width:         120,
+	}
+
+	var columns []int
+	for line := range strings.SplitSeq(m.View(), "\n") {
+		if idx := strings.Index(line, "dirty"); idx >= 0 {
+			columns = append(columns, lipgloss.Width(line[:idx]))
+		}
+	}
+	if len(columns) != 2 || columns[0] != columns[1] {
+		t.Errorf("Expected the status column to line up across rows, got offsets %v", columns)
+	}
+}
+
 // Helper function for substring checking
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && stringContains(s, substr)
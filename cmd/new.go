@@ -8,8 +8,8 @@ import (
 
 	"github.com/bshakr/koh/internal/config"
 	"github.com/bshakr/koh/internal/git"
+	"github.com/bshakr/koh/internal/multiplexer"
 	"github.com/bshakr/koh/internal/signals"
-	"github.com/bshakr/koh/internal/tmux"
 	"github.com/bshakr/koh/internal/validation"
 	"github.com/spf13/cobra"
 )
@@ -18,12 +18,12 @@ var newCmd = &cobra.Command{
 	Use:   "new <worktree-name>",
 	Short: "Create a new worktree and tmux session",
 	Long: `Create a new git worktree in .koh/<worktree-name> and open a tmux
-window with one pane running your setup script and additional panes
-running the commands from your .kohconfig.
+window (or zellij tab) with one pane running your setup script and
+additional panes running the commands from your .kohconfig.
 
-Must be run from inside a tmux session. If the setup script is missing
-from the new worktree (e.g. not committed yet), it is copied over from
-the main repository automatically.`,
+Must be run from inside a tmux or zellij session. If the setup script
+is missing from the new worktree (e.g. not committed yet), it is copied
+over from the main repository automatically.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runNew,
 }
@@ -49,12 +49,16 @@ func runNew(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a git repository\nPlease run this command from within a git repository")
 	}
 
-	// Check if we're in a tmux session before creating anything on disk. The
-	// worktree is created further down and the tmux session after it, so
-	// without this precheck a run outside tmux would create and register the
+	// Check if we're in a tmux or zellij session before creating anything on
+	// disk. The worktree is created further down and the window after it, so
+	// without this precheck a run outside either would create and register the
 	// worktree and only then fail — leaving a half-created worktree that makes
 	// re-running report "already exists".
-	if !tmux.IsInTmux() {
+	mux, err := multiplexer.Detect()
+	if err != nil {
+		return err
+	}
+	if !mux.IsInside() {
 		return fmt.Errorf("not in a tmux session\nPlease run this command from within a tmux session")
 	}
 
@@ -119,13 +123,13 @@ func runNew(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get repository name: %w", err)
 	}
 
-	// Create tmux session with config and context
-	if err := tmux.CreateSessionWithContext(ctx, repoName, worktreeName, worktreePath, cfg); err != nil {
+	// Open the window with config and context
+	if err := mux.OpenWindow(ctx, repoName, worktreeName, worktreePath, cfg); err != nil {
 		// The worktree was created moments ago; roll it back so a failed setup
 		// doesn't leave a registered, half-created worktree behind (defense in
-		// depth — the tmux precheck above covers the common cause).
+		// depth — the session precheck above covers the common cause).
 		rollbackNewWorktree(worktreeName, worktreePath)
-		return fmt.Errorf("failed to create tmux session: %w", err)
+		return fmt.Errorf("failed to create %s session: %w", mux.Name(), err)
 	}
 
 	fmt.Println("Worktree setup complete!")
@@ -10,9 +10,9 @@ import (
 	"strings"
 
 	"github.com/bshakr/koh/internal/git"
+	"github.com/bshakr/koh/internal/multiplexer"
 	"github.com/bshakr/koh/internal/signals"
 	"github.com/bshakr/koh/internal/styles"
-	"github.com/bshakr/koh/internal/tmux"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -36,7 +36,7 @@ A worktree is considered prunable when one or more of these apply:
   • gone-from-remote — its tracked upstream branch was deleted (e.g. after squash-merge)
 
 The current worktree is never pruned, and worktrees with uncommitted changes
-are skipped (use 'koh cleanup <name>' to remove those explicitly).
+are skipped (use 'koh cleanup --force <name>' to remove those explicitly).
 
 By default an interactive picker lets you confirm what gets removed. Use
 --yes to skip the picker and remove everything classified as prunable, or
@@ -72,6 +72,11 @@ func runPrune(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("not in a git repository")
 	}
 
+	mux, err := multiplexer.Detect()
+	if err != nil {
+		return err
+	}
+
 	// Silent variant: prune drives a bubbletea picker below, and the default
 	// handler's "Operation cancelled by user" print would land on top of the
 	// live TUI. Cancellation is surfaced through prune's own output instead
@@ -145,7 +150,7 @@ func runPrune(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	return executePrune(ctx, toPrune, deleteBranch)
+	return executePrune(ctx, mux, toPrune, deleteBranch)
 }
 
 // filterKohWorktrees keeps only worktrees living inside this repo's .koh
@@ -256,12 +261,12 @@ func printDryRun(candidates []pruneCandidate) {
 	fmt.Println(styles.Muted.Render(fmt.Sprintf("%d worktree(s) would be removed. Run without --dry-run to proceed.", len(candidates))))
 }
 
-// executePrune removes the chosen worktrees, closes their tmux windows, and
+// executePrune removes the chosen worktrees, closes their windows, and
 // optionally deletes their branches. Failures on a single worktree are
 // reported but do not stop the loop; an error is returned when any worktree
 // failed so the process exits non-zero.
-func executePrune(ctx context.Context, candidates []pruneCandidate, deleteBranch bool) error {
-	inTmux := tmux.IsInTmux()
+func executePrune(ctx context.Context, mux multiplexer.Multiplexer, candidates []pruneCandidate, deleteBranch bool) error {
+	inMultiplexer := mux.IsInside()
 
 	var pruned, skipped, failed int
 	var sawGone bool
@@ -297,7 +302,7 @@ func executePrune(ctx context.Context, candidates []pruneCandidate, deleteBranch
 			if dirty {
 				// Classification only looks at branch tips; never force-remove
 				// a worktree that still holds uncommitted work.
-				fmt.Printf("  %s uncommitted changes — skipped (use 'koh cleanup %s' to remove anyway)\n",
+				fmt.Printf("  %s uncommitted changes — skipped (use 'koh cleanup --force %s' to remove anyway)\n",
 					styles.Muted.Render("skip"), c.name)
 				skipped++
 				continue
@@ -316,11 +321,11 @@ func executePrune(ctx context.Context, candidates []pruneCandidate, deleteBranch
 
 		// Close the window only after the worktree is dealt with, so a failed
 		// removal doesn't kill whatever is still running in it (matches cleanup.go).
-		if inTmux {
-			if err := tmux.CloseWindow("", c.name); err != nil {
-				// Missing window is the common case (tmux already closed) — only log unexpected errors.
-				if !strings.Contains(err.Error(), "no tmux window found") {
-					fmt.Printf("  %s tmux: %v\n", styles.Muted.Render("warn"), err)
+		if inMultiplexer {
+			if err := mux.CloseWindow("", c.name); err != nil {
+				// Missing window is the common case (already closed) — only log unexpected errors.
+				if !errors.Is(err, multiplexer.ErrWindowNotFound) {
+					fmt.Printf("  %s %s: %v\n", styles.Muted.Render("warn"), mux.Name(), err)
 				}
 			}
 		}
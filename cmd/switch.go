@@ -10,7 +10,7 @@ import (
 
 	"github.com/bshakr/koh/internal/config"
 	"github.com/bshakr/koh/internal/git"
-	"github.com/bshakr/koh/internal/tmux"
+	"github.com/bshakr/koh/internal/multiplexer"
 	"github.com/bshakr/koh/internal/validation"
 	"github.com/spf13/cobra"
 )
@@ -18,12 +18,12 @@ import (
 var switchCmd = &cobra.Command{
 	Use:   "switch <worktree-name>",
 	Short: "Switch to an existing worktree's tmux session",
-	Long: `Switch to an existing worktree's tmux window.
+	Long: `Switch to an existing worktree's tmux window (or zellij tab).
 
 If the window no longer exists (e.g. after a tmux restart), it is
 recreated with the panes configured in your .kohconfig.
 
-Must be run from inside a tmux session.`,
+Must be run from inside a tmux or zellij session.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSwitch,
 }
@@ -32,7 +32,7 @@ func init() {
 	rootCmd.AddCommand(switchCmd)
 }
 
-// switchToWorktree contains the core logic for switching to a worktree's tmux session.
+// switchToWorktree contains the core logic for switching to a worktree's tmux or zellij session.
 // This function is used by both the 'switch' command and the interactive 'list' command.
 func switchToWorktree(worktreeName string, quiet bool) error {
 	// Validate worktree name for security
@@ -40,8 +40,12 @@ func switchToWorktree(worktreeName string, quiet bool) error {
 		return fmt.Errorf("invalid worktree name: %w", err)
 	}
 
-	// Check if we're in a tmux session
-	if !tmux.IsInTmux() {
+	// Check if we're in a tmux or zellij session
+	mux, err := multiplexer.Detect()
+	if err != nil {
+		return err
+	}
+	if !mux.IsInside() {
 		return fmt.Errorf("not in a tmux session\nPlease run this command from within a tmux session")
 	}
 
@@ -65,10 +69,10 @@ func switchToWorktree(worktreeName string, quiet bool) error {
 		return fmt.Errorf("failed to check worktree path: %w", err)
 	}
 
-	// Check if tmux window already exists
-	exists, err := tmux.WindowExists(worktreeName)
+	// Check if the window already exists
+	exists, err := mux.WindowExists(context.Background(), worktreeName)
 	if err != nil {
-		return fmt.Errorf("failed to check for existing tmux window: %w", err)
+		return fmt.Errorf("failed to check for existing %s window: %w", mux.Name(), err)
 	}
 
 	if exists {
@@ -76,15 +80,15 @@ func switchToWorktree(worktreeName string, quiet bool) error {
 		if !quiet {
 			fmt.Printf("Switching to existing session: .koh/%s\n", worktreeName)
 		}
-		if err := tmux.SwitchToWindow(worktreeName); err != nil {
-			return fmt.Errorf("failed to switch to tmux window: %w", err)
+		if err := mux.SwitchToWindow(context.Background(), worktreeName); err != nil {
+			return fmt.Errorf("failed to switch to %s window: %w", mux.Name(), err)
 		}
 		return nil
 	}
 
 	// Window doesn't exist, create it
 	if !quiet {
-		fmt.Printf("Creating new tmux session for existing worktree: .koh/%s\n", worktreeName)
+		fmt.Printf("Creating new %s session for existing worktree: .koh/%s\n", mux.Name(), worktreeName)
 	}
 
 	// Check if config exists
@@ -133,9 +137,9 @@ func switchToWorktree(worktreeName string, quiet bool) error {
 		return fmt.Errorf("failed to get repository name: %w", err)
 	}
 
-	// Create tmux session with config and context
-	if err := tmux.CreateSessionWithContext(ctx, repoName, worktreeName, worktreePath, cfg); err != nil {
-		return fmt.Errorf("failed to create tmux session: %w", err)
+	// Open the window with config and context
+	if err := mux.OpenWindow(ctx, repoName, worktreeName, worktreePath, cfg); err != nil {
+		return fmt.Errorf("failed to create %s session: %w", mux.Name(), err)
 	}
 
 	if !quiet {
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bshakr/koh/internal/git"
+)
+
+// EnsureSetupScript checks if the setup script exists in the worktree.
+// If not, it looks for it in the main repo root and copies it to the worktree.
+// Returns an error if the script cannot be found or copied.
+func EnsureSetupScript(worktreePath, setupScript string) error {
+	// If setup script is empty, nothing to do
+	if setupScript == "" {
+		return nil
+	}
+
+	// Check if the setup script path is absolute
+	var scriptPath string
+	if filepath.IsAbs(setupScript) {
+		scriptPath = setupScript
+	} else {
+		scriptPath = filepath.Join(worktreePath, setupScript)
+	}
+
+	// Check if the script exists in the worktree
+	if _, err := os.Stat(scriptPath); err == nil {
+		// Script exists in worktree, nothing to do
+		return nil
+	}
+
+	// Script doesn't exist in worktree, try to copy from main repo
+	// Get the main repo root
+	mainRepoRoot, err := git.GetMainRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get main repo root: %w", err)
+	}
+
+	// Check if the script exists in the main repo root
+	mainRepoScriptPath := filepath.Join(mainRepoRoot, setupScript)
+	if _, err := os.Stat(mainRepoScriptPath); os.IsNotExist(err) {
+		// Script doesn't exist in main repo either
+		return fmt.Errorf("setup script not found in worktree or main repo: %s", setupScript)
+	}
+
+	// Copy the script from main repo to worktree
+	if err := copyFile(mainRepoScriptPath, scriptPath); err != nil {
+		return fmt.Errorf("failed to copy setup script from main repo: %w", err)
+	}
+
+	return nil
+}
+
+// copyFile copies a file from src to dst
+func copyFile(src, dst string) error {
+	// Open source file
+	//nolint:gosec // G304: Opening user-specified setup script is expected
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer func() {
+		_ = sourceFile.Close() // Ignore error in defer
+	}()
+
+	// Get source file info to preserve permissions
+	sourceInfo, err := sourceFile.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat source file: %w", err)
+	}
+
+	// Create destination directory if it doesn't exist
+	dstDir := filepath.Dir(dst)
+	//nolint:gosec // G301: 0755 is standard permission for directories
+	if err := os.MkdirAll(dstDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	// Create destination file
+	//nolint:gosec // G304: Creating file in validated worktree path is expected
+	destFile, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer func() {
+		_ = destFile.Close() // Ignore error in defer
+	}()
+
+	// Copy the file content
+	if _, err := io.Copy(destFile, sourceFile); err != nil {
+		return fmt.Errorf("failed to copy file content: %w", err)
+	}
+
+	// Preserve file permissions
+	if err := os.Chmod(dst, sourceInfo.Mode()); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+
+	return nil
+}
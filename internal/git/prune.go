@@ -242,8 +242,9 @@ func PruneRefs(ctx context.Context) error {
 }
 
 // HasUncommittedChanges reports whether the worktree at path contains
-// modified or untracked files. Prune uses it as a safety check before
-// destructive removal, since classification only looks at branch tips.
+// modified, staged, or untracked files. Prune and cleanup use it as a safety
+// check before destructive removal, since classification only looks at branch
+// tips.
 func HasUncommittedChanges(ctx context.Context, path string) (bool, error) {
 	cmd := exec.CommandContext(ctx, "git", "-C", path, "status", "--porcelain")
 	output, err := cmd.Output()
@@ -253,6 +254,65 @@ func HasUncommittedChanges(ctx context.Context, path string) (bool, error) {
 	return strings.TrimSpace(string(output)) != "", nil
 }
 
+// HasUnpushedCommits reports whether the worktree at path has commits that
+// haven't been pushed. With an upstream configured that means commits ahead
+// of it. Without one (pushed without -u, never pushed, or upstream deleted) it
+// means commits on neither a remote-tracking branch nor the local default
+// branch, so a fresh branch with nothing of its own yet doesn't count even
+// when it was created from an unpushed main. Repositories without
+// remote-tracking refs compare against the default branch alone. The default
+// branch is resolved from the current directory's repository; without
+// remotes, (false, nil) is returned when it can't be found.
+func HasUnpushedCommits(ctx context.Context, path string) (bool, error) {
+	defaultBranch, _ := DefaultBranch(ctx) // may be empty; the default branch is then not excluded
+
+	args := []string{"-C", path, "rev-list", "--count"}
+	switch {
+	case exec.CommandContext(ctx, "git", "-C", path, "rev-parse", "--verify", "--quiet", "@{upstream}").Run() == nil:
+		args = append(args, "@{upstream}..HEAD")
+	case hasRemoteRefs(ctx, path):
+		args = append(args, "HEAD", "--not", "--remotes")
+		// Commits the branch shares with the default branch are the default
+		// branch's to push, not this worktree's. The default branch itself is
+		// left out so its own unpushed commits still count.
+		if defaultBranch != "" && currentBranch(ctx, path) != defaultBranch && branchExists(ctx, defaultBranch) {
+			args = append(args, "refs/heads/"+defaultBranch)
+		}
+	default:
+		base := mergeBaseRef(ctx, defaultBranch)
+		if base == "" {
+			return false, nil
+		}
+		args = append(args, base+"..HEAD")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, wrapGitError(fmt.Sprintf("git rev-list failed in %s", path), output, err)
+	}
+	return strings.TrimSpace(string(output)) != "0", nil
+}
+
+// currentBranch returns the branch checked out in the worktree at path, or an
+// empty string when HEAD is detached.
+func currentBranch(ctx context.Context, path string) string {
+	cmd := exec.CommandContext(ctx, "git", "-C", path, "symbolic-ref", "--short", "--quiet", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// hasRemoteRefs reports whether the repository at path has any
+// remote-tracking refs to compare local commits against.
+func hasRemoteRefs(ctx context.Context, path string) bool {
+	cmd := exec.CommandContext(ctx, "git", "-C", path, "for-each-ref", "--count=1", "--format=%(refname)", "refs/remotes")
+	out, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(out)) != ""
+}
+
 // ClassifyWorktrees fills in PruneReason values for each worktree.
 // ReasonGone is read straight from porcelain output; ReasonMerged and
 // ReasonGoneFromRemote require additional git calls.
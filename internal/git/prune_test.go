@@ -1,6 +1,8 @@
 package git
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -160,3 +162,129 @@ func TestUpstreamGoneNoBranch(t *testing.T) {
 		t.Error("expected empty branch -> false")
 	}
 }
+
+// scratchRepoWithOrigin creates an isolated repo with one commit on main,
+// pushed to a local bare origin, and cds into it so DefaultBranch resolves
+// against it.
+func scratchRepoWithOrigin(t *testing.T) string {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_SYSTEM", os.DevNull)
+
+	base := t.TempDir()
+	origin := filepath.Join(base, "origin.git")
+	repo := filepath.Join(base, "repo")
+	gitIn(t, base, "init", "-q", "--bare", "-b", "main", origin)
+	gitIn(t, base, "clone", "-q", origin, repo)
+	gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+	gitIn(t, repo, "push", "-q", "origin", "main")
+	t.Chdir(repo)
+	return repo
+}
+
+func TestHasUnpushedCommits(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, repo string)
+		want  bool
+	}{
+		{
+			name: "upstream ahead",
+			setup: func(t *testing.T, repo string) {
+				gitIn(t, repo, "switch", "-q", "-c", "feature")
+				gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "pushed")
+				gitIn(t, repo, "push", "-q", "-u", "origin", "feature")
+				gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "local")
+			},
+			want: true,
+		},
+		{
+			name: "upstream even",
+			setup: func(t *testing.T, repo string) {
+				gitIn(t, repo, "switch", "-q", "-c", "feature")
+				gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "pushed")
+				gitIn(t, repo, "push", "-q", "-u", "origin", "feature")
+			},
+			want: false,
+		},
+		{
+			name: "no upstream, no commits ahead",
+			setup: func(t *testing.T, repo string) {
+				gitIn(t, repo, "switch", "-q", "-c", "feature")
+			},
+			want: false,
+		},
+		{
+			name: "no upstream, commits ahead",
+			setup: func(t *testing.T, repo string) {
+				gitIn(t, repo, "switch", "-q", "-c", "feature")
+				gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "local")
+			},
+			want: true,
+		},
+		{
+			// The branch was cut from a main that is itself ahead of
+			// origin/main; main's commit isn't this branch's work.
+			name: "no upstream, branched from unpushed main",
+			setup: func(t *testing.T, repo string) {
+				gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "unpushed on main")
+				gitIn(t, repo, "switch", "-q", "-c", "feature")
+			},
+			want: false,
+		},
+		{
+			name: "unpushed commits on main itself",
+			setup: func(t *testing.T, repo string) {
+				gitIn(t, repo, "branch", "-q", "--unset-upstream")
+				gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "unpushed on main")
+			},
+			want: true,
+		},
+		{
+			name: "no upstream, pushed without -u",
+			setup: func(t *testing.T, repo string) {
+				gitIn(t, repo, "switch", "-q", "-c", "feature")
+				gitIn(t, repo, "commit", "-q", "--allow-empty", "-m", "pushed")
+				gitIn(t, repo, "push", "-q", "origin", "feature")
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scratchRepoWithOrigin(t)
+			tt.setup(t, repo)
+
+			got, err := HasUnpushedCommits(t.Context(), repo)
+			if err != nil {
+				t.Fatalf("HasUnpushedCommits() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasUnpushedCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasUnpushedCommitsWithoutRemotes(t *testing.T) {
+	// No remote-tracking refs at all: compare against the default branch.
+	repo, _, wt, _ := scratchRepoWithWorktree(t)
+	t.Chdir(repo)
+
+	got, err := HasUnpushedCommits(t.Context(), wt)
+	if err != nil {
+		t.Fatalf("HasUnpushedCommits() error: %v", err)
+	}
+	if got {
+		t.Error("expected a fresh branch with no commits ahead of main to count as pushed")
+	}
+
+	gitIn(t, wt, "commit", "-q", "--allow-empty", "-m", "local")
+	got, err = HasUnpushedCommits(t.Context(), wt)
+	if err != nil {
+		t.Fatalf("HasUnpushedCommits() error: %v", err)
+	}
+	if !got {
+		t.Error("expected commits ahead of main to count as unpushed")
+	}
+}
@@ -0,0 +1,133 @@
+// Package multiplexer abstracts the terminal multiplexer koh opens worktree
+// windows in, so commands behave the same under tmux and zellij.
+//
+// Every backend names koh's windows "<repoName>|<worktreeName>" and matches
+// them by the worktree portion, exactly as the tmux package always has. In
+// zellij those windows are tabs.
+//
+// Detection:
+// Detect picks the backend from KOH_MULTIPLEXER when it is set, and otherwise
+// from the TMUX and ZELLIJ environment variables each multiplexer exports to
+// its shells. When neither is present the no-op backend is returned, whose
+// IsInside reports false so commands skip or refuse window operations the way
+// they always did outside tmux.
+package multiplexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bshakr/koh/internal/config"
+)
+
+// EnvVar forces a specific backend: "tmux", "zellij", or "none".
+const EnvVar = "KOH_MULTIPLEXER"
+
+// ErrWindowNotFound is matched (via errors.Is) by CloseWindow and
+// SwitchToWindow errors when the worktree has no window.
+var ErrWindowNotFound = errors.New("window not found")
+
+// Multiplexer is a terminal multiplexer koh can open worktree windows in.
+type Multiplexer interface {
+	// Name is the backend's name as shown to users, e.g. "tmux".
+	Name() string
+
+	// IsInside reports whether koh is running inside this multiplexer.
+	IsInside() bool
+
+	// OpenWindow opens a "<repoName>|<worktreeName>" window in worktreePath
+	// with the setup script and pane commands from cfg.
+	OpenWindow(ctx context.Context, repoName, worktreeName, worktreePath string, cfg *config.Config) error
+
+	// CloseWindow closes the window for worktreeName along with whatever is
+	// running in it.
+	CloseWindow(windowName, worktreeName string) error
+
+	// WindowExists reports whether a window exists for worktreeName.
+	WindowExists(ctx context.Context, worktreeName string) (bool, error)
+
+	// SwitchToWindow focuses the window for worktreeName.
+	SwitchToWindow(ctx context.Context, worktreeName string) error
+
+	// WorktreeWindows returns the set of worktree names that have a window.
+	WorktreeWindows(ctx context.Context) (map[string]bool, error)
+}
+
+// Detect returns the backend to use for this process. KOH_MULTIPLEXER takes
+// precedence; otherwise tmux is checked before zellij so existing tmux users
+// (including tmux nested in zellij) keep their current behavior.
+func Detect() (Multiplexer, error) {
+	switch forced := strings.ToLower(strings.TrimSpace(os.Getenv(EnvVar))); forced {
+	case "":
+	case "tmux":
+		return Tmux{}, nil
+	case "zellij":
+		return Zellij{}, nil
+	case "none":
+		return None{}, nil
+	default:
+		return nil, fmt.Errorf("invalid %s %q: must be tmux, zellij, or none", EnvVar, forced)
+	}
+
+	if (Tmux{}).IsInside() {
+		return Tmux{}, nil
+	}
+	if (Zellij{}).IsInside() {
+		return Zellij{}, nil
+	}
+	return None{}, nil
+}
+
+// None is the backend used outside any supported multiplexer. It is never
+// inside, and its window operations fail without doing anything.
+type None struct{}
+
+// Name implements Multiplexer.
+func (None) Name() string { return "none" }
+
+// IsInside implements Multiplexer.
+func (None) IsInside() bool { return false }
+
+// OpenWindow implements Multiplexer.
+func (n None) OpenWindow(_ context.Context, _, _, _ string, _ *config.Config) error {
+	return n.notInside()
+}
+
+// CloseWindow implements Multiplexer.
+func (n None) CloseWindow(_, _ string) error { return n.notInside() }
+
+// WindowExists implements Multiplexer.
+func (None) WindowExists(_ context.Context, _ string) (bool, error) { return false, nil }
+
+// SwitchToWindow implements Multiplexer.
+func (n None) SwitchToWindow(_ context.Context, _ string) error { return n.notInside() }
+
+// WorktreeWindows implements Multiplexer.
+func (None) WorktreeWindows(_ context.Context) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+func (None) notInside() error {
+	return fmt.Errorf("not in a tmux or zellij session")
+}
+
+// windowNotFoundError keeps a backend's own message while matching
+// ErrWindowNotFound, so callers can check for it without string matching.
+type windowNotFoundError struct {
+	error
+}
+
+func (windowNotFoundError) Is(target error) bool {
+	return target == ErrWindowNotFound
+}
+
+// worktreeFromWindowName returns the worktree portion of a koh window name.
+// Only the FIRST "|" separates repo from worktree, so worktree names that
+// themselves contain "|" are recovered intact (matching the tmux parser).
+func worktreeFromWindowName(name string) (string, bool) {
+	_, worktree, ok := strings.Cut(name, "|")
+	return worktree, ok
+}
@@ -0,0 +1,127 @@
+package multiplexer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name   string
+		forced string
+		tmux   string
+		zellij string
+		want   Multiplexer
+	}{
+		{"neither", "", "", "", None{}},
+		{"tmux", "", "/tmp/tmux-1000/default,1,0", "", Tmux{}},
+		{"zellij", "", "", "0", Zellij{}},
+		{"tmux wins when nested", "", "/tmp/tmux-1000/default,1,0", "0", Tmux{}},
+		{"forced zellij", "zellij", "/tmp/tmux-1000/default,1,0", "", Zellij{}},
+		{"forced tmux", "TMUX", "", "0", Tmux{}},
+		{"forced none", "none", "/tmp/tmux-1000/default,1,0", "", None{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvVar, tt.forced)
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("ZELLIJ", tt.zellij)
+
+			mux, err := Detect()
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+			if mux != tt.want {
+				t.Errorf("Detect() = %T, want %T", mux, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectInvalidOverride(t *testing.T) {
+	t.Setenv(EnvVar, "screen")
+
+	if _, err := Detect(); err == nil {
+		t.Error("expected an error for an unsupported KOH_MULTIPLEXER value")
+	}
+}
+
+func TestForcedBackendStillReportsIsInside(t *testing.T) {
+	// Forcing a backend selects it, but IsInside still reflects reality so
+	// commands refuse to run outside a session instead of failing midway.
+	t.Setenv(EnvVar, "zellij")
+	t.Setenv("ZELLIJ", "")
+
+	mux, err := Detect()
+	if err != nil {
+		t.Fatalf("Detect() error: %v", err)
+	}
+	if mux.IsInside() {
+		t.Error("expected forced zellij backend to report not inside without ZELLIJ set")
+	}
+}
+
+func TestNoneNeverOpensOrCloses(t *testing.T) {
+	var mux None
+	if mux.IsInside() {
+		t.Error("expected None to never be inside")
+	}
+	if err := mux.OpenWindow(t.Context(), "repo", "wt", "/tmp", nil); err == nil {
+		t.Error("expected None.OpenWindow to fail")
+	}
+	if err := mux.CloseWindow("repo|wt", "wt"); err == nil {
+		t.Error("expected None.CloseWindow to fail")
+	}
+	exists, err := mux.WindowExists(t.Context(), "wt")
+	if err != nil || exists {
+		t.Errorf("expected None.WindowExists = (false, nil), got (%v, %v)", exists, err)
+	}
+}
+
+func TestWindowNotFoundError(t *testing.T) {
+	err := windowNotFoundError{fmt.Errorf("no zellij tab found for worktree: wt")}
+	if !errors.Is(err, ErrWindowNotFound) {
+		t.Error("expected errors.Is(err, ErrWindowNotFound)")
+	}
+	if err.Error() != "no zellij tab found for worktree: wt" {
+		t.Errorf("expected the backend's message to be kept, got %q", err.Error())
+	}
+}
+
+func TestTmuxWindowError(t *testing.T) {
+	missing := fmt.Errorf("no tmux window found for worktree: wt")
+	if got := tmuxWindowError(missing); !errors.Is(got, ErrWindowNotFound) || got.Error() != missing.Error() {
+		t.Errorf("expected tagged error with unchanged message, got %v", got)
+	}
+
+	other := fmt.Errorf("failed to close tmux window: exit status 1")
+	if got := tmuxWindowError(other); errors.Is(got, ErrWindowNotFound) {
+		t.Error("expected unrelated tmux errors not to match ErrWindowNotFound")
+	}
+	if tmuxWindowError(nil) != nil {
+		t.Error("expected nil to stay nil")
+	}
+}
+
+func TestWorktreeFromWindowName(t *testing.T) {
+	tests := []struct {
+		name         string
+		window       string
+		wantWorktree string
+		wantOK       bool
+	}{
+		{"plain name", "repo|feature", "feature", true},
+		{"worktree with pipe", "repo|a|b", "a|b", true},
+		{"not a koh window", "scratch", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worktree, ok := worktreeFromWindowName(tt.window)
+			if worktree != tt.wantWorktree || ok != tt.wantOK {
+				t.Errorf("worktreeFromWindowName(%q) = (%q, %v), want (%q, %v)",
+					tt.window, worktree, ok, tt.wantWorktree, tt.wantOK)
+			}
+		})
+	}
+}
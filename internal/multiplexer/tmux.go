@@ -0,0 +1,52 @@
+package multiplexer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bshakr/koh/internal/config"
+	"github.com/bshakr/koh/internal/tmux"
+)
+
+// Tmux is the tmux backend. It delegates to the tmux package unchanged.
+type Tmux struct{}
+
+// Name implements Multiplexer.
+func (Tmux) Name() string { return "tmux" }
+
+// IsInside implements Multiplexer.
+func (Tmux) IsInside() bool { return tmux.IsInTmux() }
+
+// OpenWindow implements Multiplexer.
+func (Tmux) OpenWindow(ctx context.Context, repoName, worktreeName, worktreePath string, cfg *config.Config) error {
+	return tmux.CreateSessionWithContext(ctx, repoName, worktreeName, worktreePath, cfg)
+}
+
+// CloseWindow implements Multiplexer.
+func (Tmux) CloseWindow(windowName, worktreeName string) error {
+	return tmuxWindowError(tmux.CloseWindow(windowName, worktreeName))
+}
+
+// WindowExists implements Multiplexer.
+func (Tmux) WindowExists(ctx context.Context, worktreeName string) (bool, error) {
+	return tmux.WindowExistsWithContext(ctx, worktreeName)
+}
+
+// SwitchToWindow implements Multiplexer.
+func (Tmux) SwitchToWindow(ctx context.Context, worktreeName string) error {
+	return tmuxWindowError(tmux.SwitchToWindowWithContext(ctx, worktreeName))
+}
+
+// WorktreeWindows implements Multiplexer.
+func (Tmux) WorktreeWindows(ctx context.Context) (map[string]bool, error) {
+	return tmux.WorktreeWindowsWithContext(ctx)
+}
+
+// tmuxWindowError tags the tmux package's missing-window error so it matches
+// ErrWindowNotFound, leaving its message untouched.
+func tmuxWindowError(err error) error {
+	if err != nil && strings.Contains(err.Error(), "no tmux window found") {
+		return windowNotFoundError{err}
+	}
+	return err
+}
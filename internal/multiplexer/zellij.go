@@ -0,0 +1,384 @@
+package multiplexer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bshakr/koh/internal/config"
+)
+
+// Zellij is the zellij backend. Each koh window is a zellij tab named
+// "<repoName>|<worktreeName>", driven through "zellij action".
+//
+// Like the tmux backend, pane commands come from the user's .kohconfig and are
+// typed into interactive shells; see the tmux package for the trust model.
+type Zellij struct{}
+
+// Name implements Multiplexer.
+func (Zellij) Name() string { return "zellij" }
+
+// IsInside implements Multiplexer. zellij exports ZELLIJ to every pane.
+func (Zellij) IsInside() bool { return os.Getenv("ZELLIJ") != "" }
+
+// OpenWindow implements Multiplexer. The layout matches the tmux backend: the
+// setup script runs in the tab's first pane, the first pane command opens to
+// its right, and each further command goes under the pane created two steps
+// before it, alternating between the two columns. Focus returns to the setup
+// pane at the end.
+func (z Zellij) OpenWindow(ctx context.Context, repoName, worktreeName, worktreePath string, cfg *config.Config) error {
+	if !z.IsInside() {
+		return fmt.Errorf("not in a zellij session")
+	}
+
+	// Ensure the setup script is available (copy from main repo if needed)
+	if err := config.EnsureSetupScript(worktreePath, cfg.SetupScript); err != nil {
+		return fmt.Errorf("failed to ensure setup script: %w", err)
+	}
+
+	tabName := fmt.Sprintf("%s|%s", repoName, worktreeName)
+	if err := runZellijAction(ctx, "new-tab", "--name", tabName, "--cwd", worktreePath); err != nil {
+		return fmt.Errorf("failed to create zellij tab: %w", err)
+	}
+
+	// New tabs and panes take focus, so each command is typed into the pane
+	// just created.
+	if cfg.SetupScript != "" {
+		if err := typeCommand(ctx, cfg.SetupScript); err != nil {
+			return err
+		}
+	}
+
+	// zellij splits the focused pane and picks panes by direction rather than
+	// index, so before each split focus moves to the bottom of the column the
+	// new pane belongs in. columns counts the panes in the left (setup) and
+	// right columns.
+	columns := [2]int{1, 0}
+	for i, cmd := range cfg.PaneCommands {
+		column := (i + 1) % 2
+		direction := "right"
+		if i > 0 {
+			direction = "down"
+			if err := focusColumnEdge(ctx, column, "down", columns[column]); err != nil {
+				return err
+			}
+		}
+		if err := runZellijAction(ctx, "new-pane", "--direction", direction, "--cwd", worktreePath); err != nil {
+			return err
+		}
+		columns[column]++
+		if err := typeCommand(ctx, cmd); err != nil {
+			return err
+		}
+	}
+
+	// Focus on the first pane (setup)
+	if len(cfg.PaneCommands) > 0 {
+		return focusColumnEdge(ctx, 0, "up", columns[0])
+	}
+	return nil
+}
+
+// focusColumnEdge moves focus to the top ("up") or bottom ("down") pane of
+// column 0 (left) or 1 (right) in a tab laid out by OpenWindow; panes is the
+// number of panes in that column. Moves past the edge of the tab are no-ops,
+// so it works from any pane.
+func focusColumnEdge(ctx context.Context, column int, edge string, panes int) error {
+	side := "left"
+	if column == 1 {
+		side = "right"
+	}
+	if err := runZellijAction(ctx, "move-focus", side); err != nil {
+		return err
+	}
+	for range panes - 1 {
+		if err := runZellijAction(ctx, "move-focus", edge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CloseWindow implements Multiplexer. Like the tmux backend it sends Ctrl-C to
+// every pane and waits 500ms so processes can exit gracefully before the tab
+// is closed. zellij only acts on the focused tab and pane, so the tab is
+// focused first and the focus is re-checked before anything is sent or closed;
+// a tab focused by someone else in the meantime is never closed. Focus then
+// returns to the tab the user was on, as tmux never moves it to kill a window.
+// Note: Uses context.Background() to ensure cleanup completes even if the
+// caller's context is cancelled (matching the tmux backend).
+func (Zellij) CloseWindow(_ /* windowName */, worktreeName string) error {
+	ctx := context.Background()
+	name, err := findTabByWorktree(ctx, worktreeName)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return windowNotFoundError{fmt.Errorf("no zellij tab found for worktree: %s", worktreeName)}
+	}
+
+	// Best effort: an empty name just skips restoring focus.
+	original, _, _ := focusedTab(ctx)
+
+	if err := runZellijAction(ctx, "go-to-tab-name", name); err != nil {
+		return fmt.Errorf("failed to switch to zellij tab: %w", err)
+	}
+	// When the user was on the closed tab itself, zellij moves to a
+	// neighbouring tab, like tmux switching to the previous window.
+	defer func() {
+		if original == "" || original == name {
+			return
+		}
+		if err := runZellijAction(ctx, "go-to-tab-name", original); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to return to zellij tab %s: %v\n", original, err)
+		}
+	}()
+
+	panes, err := checkFocusedTab(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Send Ctrl-C to each pane to gracefully terminate processes, moving
+	// focus round the tab one pane at a time.
+	for i := range panes {
+		if err := runZellijAction(ctx, "write", "3"); err != nil {
+			// Log the error but continue with other panes
+			fmt.Fprintf(os.Stderr, "Warning: failed to send Ctrl-C to pane %d in zellij tab %s: %v\n", i, name, err)
+		}
+		if err := runZellijAction(ctx, "focus-next-pane"); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to focus next pane in zellij tab %s: %v\n", name, err)
+		}
+	}
+
+	// Wait a moment for processes to terminate gracefully
+	if panes > 0 {
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	// close-tab closes whichever tab is focused, so make sure it is still ours.
+	if _, err := checkFocusedTab(ctx, name); err != nil {
+		return err
+	}
+	if err := runZellijAction(ctx, "close-tab"); err != nil {
+		return fmt.Errorf("failed to close zellij tab: %w", err)
+	}
+	return nil
+}
+
+// checkFocusedTab returns the number of panes in the focused tab, or an error
+// if the focused tab isn't the one named want.
+func checkFocusedTab(ctx context.Context, want string) (int, error) {
+	name, panes, err := focusedTab(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if name != want {
+		return 0, fmt.Errorf("zellij tab %s is no longer focused, not closing it", want)
+	}
+	return panes, nil
+}
+
+// focusedTab returns the name of the focused tab and how many panes it has.
+func focusedTab(ctx context.Context) (string, int, error) {
+	cmd := exec.CommandContext(ctx, "zellij", "action", "dump-layout")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read zellij layout: %w", err)
+	}
+	name, panes, ok := parseFocusedTab(string(output))
+	if !ok {
+		return "", 0, fmt.Errorf("no focused zellij tab found")
+	}
+	return name, panes, nil
+}
+
+// layoutNode is an open block in "zellij action dump-layout" output.
+type layoutNode struct {
+	kind     string
+	hasPane  bool // contains a nested pane, so it is a split, not a pane itself
+	plugin   bool // hosts a plugin such as the tab or status bar
+	floating bool // inside a floating_panes block
+}
+
+var layoutNameRe = regexp.MustCompile(`\bname=("(?:[^"\\]|\\.)*")`)
+
+// parseFocusedTab finds the tab marked focus=true in "zellij action
+// dump-layout" output (a KDL document, one node per line) and returns its name
+// and the number of tiled terminal panes in it. Splits, plugin panes, and
+// floating panes aren't counted.
+func parseFocusedTab(layout string) (name string, panes int, ok bool) {
+	var stack []*layoutNode
+	inFocused := false
+	for raw := range strings.SplitSeq(layout, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		if line == "}" {
+			if len(stack) == 0 {
+				continue
+			}
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			switch {
+			case node.kind == "tab":
+				inFocused = false
+			case inFocused && node.kind == "pane" && !node.hasPane && !node.plugin && !node.floating:
+				panes++
+			}
+			continue
+		}
+
+		kind, _, _ := strings.Cut(line, " ")
+		kind = strings.TrimSuffix(kind, "{")
+		var parent *layoutNode
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+			switch kind {
+			case "pane":
+				parent.hasPane = true
+			case "plugin":
+				parent.plugin = true
+			}
+		}
+
+		if kind == "tab" && !ok && strings.Contains(line, "focus=true") {
+			if m := layoutNameRe.FindStringSubmatch(line); m != nil {
+				if unquoted, err := strconv.Unquote(m[1]); err == nil {
+					name, ok, inFocused = unquoted, true, true
+				}
+			}
+		}
+
+		floating := kind == "floating_panes" || (parent != nil && parent.floating)
+		if strings.HasSuffix(line, "{") {
+			stack = append(stack, &layoutNode{kind: kind, floating: floating})
+		} else if inFocused && kind == "pane" && !floating {
+			panes++
+		}
+	}
+	return name, panes, ok
+}
+
+// WindowExists implements Multiplexer.
+func (Zellij) WindowExists(ctx context.Context, worktreeName string) (bool, error) {
+	name, err := findTabByWorktree(ctx, worktreeName)
+	if err != nil {
+		return false, err
+	}
+	return name != "", nil
+}
+
+// SwitchToWindow implements Multiplexer.
+func (Zellij) SwitchToWindow(ctx context.Context, worktreeName string) error {
+	name, err := findTabByWorktree(ctx, worktreeName)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return windowNotFoundError{fmt.Errorf("no zellij tab found for worktree: %s", worktreeName)}
+	}
+
+	if err := runZellijAction(ctx, "go-to-tab-name", name); err != nil {
+		return fmt.Errorf("failed to switch to zellij tab: %w", err)
+	}
+	return nil
+}
+
+// WorktreeWindows implements Multiplexer.
+func (Zellij) WorktreeWindows(ctx context.Context) (map[string]bool, error) {
+	names, err := listTabNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make(map[string]bool)
+	for _, name := range names {
+		if worktree, ok := worktreeFromWindowName(name); ok {
+			windows[worktree] = true
+		}
+	}
+	return windows, nil
+}
+
+// listTabNames returns the names of all tabs in the current zellij session.
+func listTabNames(ctx context.Context) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "zellij", "action", "query-tab-names")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zellij tabs: %w", err)
+	}
+	return parseTabNames(string(output)), nil
+}
+
+// parseTabNames splits "zellij action query-tab-names" output, one tab name
+// per line, dropping blank lines.
+func parseTabNames(output string) []string {
+	var names []string
+	for line := range strings.SplitSeq(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			names = append(names, line)
+		}
+	}
+	return names
+}
+
+// findTabByWorktree returns the full name of the tab for worktreeName, or an
+// empty string if there is none.
+func findTabByWorktree(ctx context.Context, worktreeName string) (string, error) {
+	names, err := listTabNames(ctx)
+	if err != nil {
+		return "", err
+	}
+	return matchTabName(names, worktreeName), nil
+}
+
+// matchTabName returns the first koh tab name whose worktree portion is
+// worktreeName, or an empty string if none match.
+func matchTabName(names []string, worktreeName string) string {
+	for _, name := range names {
+		if worktree, ok := worktreeFromWindowName(name); ok && worktree == worktreeName {
+			return name
+		}
+	}
+	return ""
+}
+
+// typeCommand types a command into the focused pane and presses Enter.
+//
+// Security: command comes from the user's .kohconfig file (see the tmux
+// package documentation for the trust model).
+func typeCommand(ctx context.Context, command string) error {
+	if err := runZellijAction(ctx, "write-chars", command); err != nil {
+		return err
+	}
+	// 13 is carriage return, i.e. Enter.
+	return runZellijAction(ctx, "write", "13")
+}
+
+// zellijActionRunner runs "zellij action" with args. Tests replace it to
+// record the actions a backend method sends.
+var zellijActionRunner = func(ctx context.Context, args ...string) error {
+	//nolint:gosec // G204: zellij commands with validated parameters are safe
+	cmd := exec.CommandContext(ctx, "zellij", append([]string{"action"}, args...)...)
+	return cmd.Run()
+}
+
+// runZellijAction runs "zellij action" with the given arguments with cancellation support
+func runZellijAction(ctx context.Context, args ...string) error {
+	if err := zellijActionRunner(ctx, args...); err != nil {
+		if ctx.Err() == context.Canceled {
+			return fmt.Errorf("operation cancelled")
+		}
+		return fmt.Errorf("zellij command failed (%v): %w", args, err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package multiplexer
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bshakr/koh/internal/config"
+)
+
+// These tests cover the parsing of zellij output and the actions sent to lay
+// out a tab. None of them need a live zellij session.
+
+func TestParseTabNames(t *testing.T) {
+	got := parseTabNames("Tab #1\nrepo|feature\r\n\nrepo|a|b\n")
+	want := []string{"Tab #1", "repo|feature", "repo|a|b"}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseTabNames() = %q, want %q", got, want)
+	}
+}
+
+func TestParseTabNamesEmpty(t *testing.T) {
+	if got := parseTabNames(""); len(got) != 0 {
+		t.Errorf("expected no tab names, got %q", got)
+	}
+}
+
+func TestMatchTabName(t *testing.T) {
+	names := []string{"Tab #1", "repo|feature", "repo|a|b"}
+
+	tests := []struct {
+		worktree string
+		want     string
+	}{
+		{"feature", "repo|feature"},
+		{"a|b", "repo|a|b"},
+		{"missing", ""},
+		// "Tab #1" has no koh separator and must never match.
+		{"Tab #1", ""},
+	}
+	for _, tt := range tests {
+		if got := matchTabName(names, tt.worktree); got != tt.want {
+			t.Errorf("matchTabName(%q) = %q, want %q", tt.worktree, got, tt.want)
+		}
+	}
+}
+
+// sampleLayout is trimmed "zellij action dump-layout" output for a session
+// where koh's tab (setup pane plus two commands) is focused.
+const sampleLayout = `layout {
+    cwd "/home/dev"
+    tab name="Tab #1" hide_floating_panes=true {
+        pane size=1 borderless=true {
+            plugin location="zellij:tab-bar"
+        }
+        pane
+        pane size=2 borderless=true {
+            plugin location="zellij:status-bar"
+        }
+    }
+    tab name="repo|feature" focus=true hide_floating_panes=true {
+        pane size=1 borderless=true {
+            plugin location="zellij:tab-bar"
+        }
+        pane split_direction="vertical" {
+            pane split_direction="horizontal" size="50%" {
+                pane cwd="/home/dev/repo/.koh/feature" focus=true
+                pane command="npm" cwd="/home/dev/repo/.koh/feature" {
+                    args "run" "dev"
+                }
+            }
+            pane cwd="/home/dev/repo/.koh/feature" size="50%"
+        }
+        pane size=2 borderless=true {
+            plugin location="zellij:status-bar"
+        }
+        floating_panes {
+            pane x=10 y=5
+        }
+    }
+    new_tab_template {
+        pane
+    }
+}
+`
+
+func TestParseFocusedTab(t *testing.T) {
+	name, panes, ok := parseFocusedTab(sampleLayout)
+	if !ok {
+		t.Fatal("expected a focused tab")
+	}
+	if name != "repo|feature" {
+		t.Errorf("name = %q, want %q", name, "repo|feature")
+	}
+	if panes != 3 {
+		t.Errorf("panes = %d, want 3", panes)
+	}
+}
+
+func TestParseFocusedTabNone(t *testing.T) {
+	if _, _, ok := parseFocusedTab("layout {\n    tab name=\"Tab #1\" {\n        pane\n    }\n}\n"); ok {
+		t.Error("expected no focused tab")
+	}
+	if _, _, ok := parseFocusedTab(""); ok {
+		t.Error("expected no focused tab in empty output")
+	}
+}
+
+// recordZellijActions replaces the zellij runner for one test and returns the
+// actions sent, one space-joined line per call.
+func recordZellijActions(t *testing.T) *[]string {
+	t.Helper()
+	var actions []string
+	old := zellijActionRunner
+	zellijActionRunner = func(_ context.Context, args ...string) error {
+		actions = append(actions, strings.Join(args, " "))
+		return nil
+	}
+	t.Cleanup(func() { zellijActionRunner = old })
+	return &actions
+}
+
+// TestZellijOpenWindowLayout checks that panes follow the tmux layout: the
+// first command opens right of setup, and each later command splits the pane
+// created two steps before it (the bottom of the other column).
+func TestZellijOpenWindowLayout(t *testing.T) {
+	const newTab = "new-tab --name repo|wt --cwd /wt"
+	tests := []struct {
+		name     string
+		commands []string
+		want     []string
+	}{
+		{
+			name: "no pane commands",
+			want: []string{newTab},
+		},
+		{
+			name:     "one pane command",
+			commands: []string{"a"},
+			want: []string{
+				newTab,
+				"new-pane --direction right --cwd /wt", "write-chars a", "write 13",
+				"move-focus left",
+			},
+		},
+		{
+			name:     "three pane commands",
+			commands: []string{"a", "b", "c"},
+			want: []string{
+				newTab,
+				"new-pane --direction right --cwd /wt", "write-chars a", "write 13",
+				// b goes under setup
+				"move-focus left",
+				"new-pane --direction down --cwd /wt", "write-chars b", "write 13",
+				// c goes under a
+				"move-focus right",
+				"new-pane --direction down --cwd /wt", "write-chars c", "write 13",
+				// back to setup, the top of the left column
+				"move-focus left", "move-focus up",
+			},
+		},
+		{
+			name:     "four pane commands",
+			commands: []string{"a", "b", "c", "d"},
+			want: []string{
+				newTab,
+				"new-pane --direction right --cwd /wt", "write-chars a", "write 13",
+				"move-focus left",
+				"new-pane --direction down --cwd /wt", "write-chars b", "write 13",
+				"move-focus right",
+				"new-pane --direction down --cwd /wt", "write-chars c", "write 13",
+				// d goes under b, the bottom of the left column
+				"move-focus left", "move-focus down",
+				"new-pane --direction down --cwd /wt", "write-chars d", "write 13",
+				"move-focus left", "move-focus up", "move-focus up",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ZELLIJ", "0")
+			actions := recordZellijActions(t)
+
+			cfg := &config.Config{PaneCommands: tt.commands}
+			if err := (Zellij{}).OpenWindow(t.Context(), "repo", "wt", "/wt", cfg); err != nil {
+				t.Fatalf("OpenWindow() error: %v", err)
+			}
+			if !slices.Equal(*actions, tt.want) {
+				t.Errorf("actions:\n  got  %q\n  want %q", *actions, tt.want)
+			}
+		})
+	}
+}
@@ -37,15 +37,12 @@ package tmux
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/bshakr/koh/internal/config"
-	"github.com/bshakr/koh/internal/git"
 )
 
 // IsInTmux checks if the current session is running inside tmux
@@ -53,99 +50,6 @@ func IsInTmux() bool {
 	return os.Getenv("TMUX") != ""
 }
 
-// ensureSetupScript checks if the setup script exists in the worktree.
-// If not, it looks for it in the main repo root and copies it to the worktree.
-// Returns an error if the script cannot be found or copied.
-func ensureSetupScript(worktreePath, setupScript string) error {
-	// If setup script is empty, nothing to do
-	if setupScript == "" {
-		return nil
-	}
-
-	// Check if the setup script path is absolute
-	var scriptPath string
-	if filepath.IsAbs(setupScript) {
-		scriptPath = setupScript
-	} else {
-		scriptPath = filepath.Join(worktreePath, setupScript)
-	}
-
-	// Check if the script exists in the worktree
-	if _, err := os.Stat(scriptPath); err == nil {
-		// Script exists in worktree, nothing to do
-		return nil
-	}
-
-	// Script doesn't exist in worktree, try to copy from main repo
-	// Get the main repo root
-	mainRepoRoot, err := git.GetMainRepoRoot()
-	if err != nil {
-		return fmt.Errorf("failed to get main repo root: %w", err)
-	}
-
-	// Check if the script exists in the main repo root
-	mainRepoScriptPath := filepath.Join(mainRepoRoot, setupScript)
-	if _, err := os.Stat(mainRepoScriptPath); os.IsNotExist(err) {
-		// Script doesn't exist in main repo either
-		return fmt.Errorf("setup script not found in worktree or main repo: %s", setupScript)
-	}
-
-	// Copy the script from main repo to worktree
-	if err := copyFile(mainRepoScriptPath, scriptPath); err != nil {
-		return fmt.Errorf("failed to copy setup script from main repo: %w", err)
-	}
-
-	return nil
-}
-
-// copyFile copies a file from src to dst
-func copyFile(src, dst string) error {
-	// Open source file
-	//nolint:gosec // G304: Opening user-specified setup script is expected
-	sourceFile, err := os.Open(src)
-	if err != nil {
-		return fmt.Errorf("failed to open source file: %w", err)
-	}
-	defer func() {
-		_ = sourceFile.Close() // Ignore error in defer
-	}()
-
-	// Get source file info to preserve permissions
-	sourceInfo, err := sourceFile.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to stat source file: %w", err)
-	}
-
-	// Create destination directory if it doesn't exist
-	dstDir := filepath.Dir(dst)
-	//nolint:gosec // G301: 0755 is standard permission for directories
-	if err := os.MkdirAll(dstDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create destination directory: %w", err)
-	}
-
-	// Create destination file
-	//nolint:gosec // G304: Creating file in validated worktree path is expected
-	destFile, err := os.Create(dst)
-	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
-	}
-	defer func() {
-		_ = destFile.Close() // Ignore error in defer
-	}()
-
-	// Copy the file content
-	if _, err := io.Copy(destFile, sourceFile); err != nil {
-		return fmt.Errorf("failed to copy file content: %w", err)
-	}
-
-	// Preserve file permissions
-	if err := os.Chmod(dst, sourceInfo.Mode()); err != nil {
-		return fmt.Errorf("failed to set file permissions: %w", err)
-	}
-
-	return nil
-}
-
 // CreateSession creates a new tmux window with dynamically created panes based on the provided config
 func CreateSession(repoName, worktreeName, worktreePath string, cfg *config.Config) error {
 	return CreateSessionWithContext(context.Background(), repoName, worktreeName, worktreePath, cfg)
@@ -158,7 +62,7 @@ func CreateSessionWithContext(ctx context.Context, repoName, worktreeName, workt
 	}
 
 	// Ensure the setup script is available (copy from main repo if needed)
-	if err := ensureSetupScript(worktreePath, cfg.SetupScript); err != nil {
+	if err := config.EnsureSetupScript(worktreePath, cfg.SetupScript); err != nil {
 		return fmt.Errorf("failed to ensure setup script: %w", err)
 	}
 
@@ -426,6 +330,30 @@ func getPaneBaseIndex(ctx context.Context) (int, error) {
 	return baseIndex, nil
 }
 
+// WorktreeWindowsWithContext returns the set of worktree names that currently
+// have a koh window ("repo|worktree") in tmux. It lists the windows once, so
+// callers checking many worktrees (e.g. koh list) don't pay a tmux call each.
+func WorktreeWindowsWithContext(ctx context.Context) (map[string]bool, error) {
+	cmd := exec.CommandContext(ctx, "tmux", "list-windows", "-F", "#{window_index}:#{window_name}")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+
+	windows := make(map[string]bool)
+	for line := range strings.SplitSeq(string(output), "\n") {
+		if _, _, worktree, ok := parseWindowLine(line); ok {
+			windows[worktree] = true
+		}
+	}
+	return windows, nil
+}
+
+// WorktreeWindows returns the set of worktree names that currently have a tmux window
+func WorktreeWindows() (map[string]bool, error) {
+	return WorktreeWindowsWithContext(context.Background())
+}
+
 // WindowExistsWithContext checks if a tmux window exists for the given worktree name with context support
 func WindowExistsWithContext(ctx context.Context, worktreeName string) (bool, error) {
 	index, _, err := findWindowByWorktree(ctx, worktreeName)
@@ -449,6 +449,41 @@ func TestWindowExistsAfterCreation(t *testing.T) {
 	}
 }
 
+// TestWorktreeWindows tests that WorktreeWindows reports a created window and
+// leaves out worktrees without one
+func TestWorktreeWindows(t *testing.T) {
+	if !IsInTmux() {
+		t.Skip("Not in a tmux session, skipping test")
+	}
+
+	worktreeName := "test-list-windows"
+	cfg := &config.Config{
+		SetupScript:  "",
+		PaneCommands: []string{},
+	}
+
+	ctx := context.Background()
+	if err := CreateSessionWithContext(ctx, "test-repo", worktreeName, "/tmp", cfg); err != nil {
+		t.Fatalf("Failed to create test window: %v", err)
+	}
+	defer func() {
+		if err := CloseWindow("test-repo", worktreeName); err != nil {
+			t.Logf("Failed to close window: %v", err)
+		}
+	}()
+
+	windows, err := WorktreeWindows()
+	if err != nil {
+		t.Fatalf("WorktreeWindows() error: %v", err)
+	}
+	if !windows[worktreeName] {
+		t.Errorf("Expected %q in WorktreeWindows result, got %v", worktreeName, windows)
+	}
+	if windows["nonexistent-worktree-list-12345"] {
+		t.Error("Expected non-existent worktree to be absent from WorktreeWindows result")
+	}
+}
+
 // TestGetPanesForWindow tests getting pane IDs for a window
 func TestGetPanesForWindow(t *testing.T) {
 	if !IsInTmux() {